@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultDuration is used when Duration is left unset in the configuration file.
+	DefaultDuration = time.Hour * 24
+
+	// DefaultKeyAlgorithm is used when CMKeyAlgorithm is left unset in the configuration file.
+	DefaultKeyAlgorithm = "ECDSA"
+
+	// DefaultKeySize is used when KeySize is left unset in the configuration file.
+	DefaultKeySize = 256
+)
+
+// SetDefaults_IstiodCertProvisionerConfiguration applies defaults to an IstiodCertProvisionerConfiguration
+// loaded from a configuration file, for any fields left unset.
+func SetDefaults_IstiodCertProvisionerConfiguration(cfg *IstiodCertProvisionerConfiguration) {
+	if cfg.Duration.Duration == 0 {
+		cfg.Duration = metav1.Duration{Duration: DefaultDuration}
+	}
+
+	if cfg.RenewBefore.Duration == 0 {
+		cfg.RenewBefore = metav1.Duration{Duration: cfg.Duration.Duration / 3}
+	}
+
+	if len(cfg.IstioRevisions) == 0 {
+		cfg.IstioRevisions = []string{"default"}
+	}
+
+	if cfg.CMKeyAlgorithm == "" {
+		cfg.CMKeyAlgorithm = DefaultKeyAlgorithm
+	}
+
+	if cfg.KeySize == 0 {
+		cfg.KeySize = DefaultKeySize
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IstiodCertProvisionerConfiguration is the file-based configuration for the istiod cert provisioner
+// component. It mirrors istiodcert.Options, and is loaded from a YAML file passed via the --config flag.
+type IstiodCertProvisionerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CertificateName is the name of the Certificate resource used to manage the istiod cert.
+	CertificateName string `json:"certificateName"`
+
+	// CertificateNamespace is the namespace the istiod Certificate resource lives in.
+	CertificateNamespace string `json:"certificateNamespace"`
+
+	// Duration is the requested duration of the istiod certificate.
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is the period of time before the certificate's expiry at which it should be renewed.
+	// Defaults to a third of Duration if unset.
+	// +optional
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
+
+	// IstioRevisions is the list of istio revisions to provision the istiod cert for.
+	// +optional
+	IstioRevisions []string `json:"istioRevisions,omitempty"`
+
+	// AdditionalDNSNames are additional DNS SANs to request on the istiod cert, on top of those derived
+	// from IstioRevisions.
+	// +optional
+	AdditionalDNSNames []string `json:"additionalDNSNames,omitempty"`
+
+	// CMKeyAlgorithm is the private key algorithm to request from cert-manager for the istiod cert.
+	// Defaults to "ECDSA".
+	// +optional
+	CMKeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is the private key size to request from cert-manager for the istiod cert.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// PerRevisionCerts, when true, provisions one Certificate per configured istio revision instead of a
+	// single Certificate covering every revision.
+	// +optional
+	PerRevisionCerts bool `json:"perRevisionCerts,omitempty"`
+
+	// AllowedIssuerKinds is the list of cert-manager issuer Kinds istio-csr is permitted to use for the
+	// istiod cert. An incoming issuer change whose Kind isn't in this list is rejected. Defaults to
+	// "Issuer" and "ClusterIssuer" if left unset.
+	// +optional
+	AllowedIssuerKinds []string `json:"allowedIssuerKinds,omitempty"`
+
+	// AllowedIssuerGroups is the list of cert-manager issuer API groups istio-csr is permitted to use for
+	// the istiod cert. An incoming issuer change whose Group isn't in this list is rejected. An empty list
+	// allows any group.
+	// +optional
+	AllowedIssuerGroups []string `json:"allowedIssuerGroups,omitempty"`
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+)
+
+// validKeyAlgorithms are the cert-manager private key algorithms this component knows how to request.
+var validKeyAlgorithms = map[string]bool{
+	"RSA":     true,
+	"ECDSA":   true,
+	"Ed25519": true,
+}
+
+// ValidateIstiodCertProvisionerConfiguration validates a (defaulted) IstiodCertProvisionerConfiguration,
+// returning an error describing the first problem found.
+func ValidateIstiodCertProvisionerConfiguration(cfg *IstiodCertProvisionerConfiguration) error {
+	if cfg.CertificateName == "" {
+		return fmt.Errorf("certificateName must be set")
+	}
+
+	if cfg.CertificateNamespace == "" {
+		return fmt.Errorf("certificateNamespace must be set")
+	}
+
+	if cfg.Duration.Duration <= 0 {
+		return fmt.Errorf("duration must be greater than zero, got %q", cfg.Duration.Duration)
+	}
+
+	if cfg.RenewBefore.Duration <= 0 {
+		return fmt.Errorf("renewBefore must be greater than zero, got %q", cfg.RenewBefore.Duration)
+	}
+
+	if cfg.RenewBefore.Duration >= cfg.Duration.Duration {
+		return fmt.Errorf("renewBefore (%q) must be less than duration (%q)", cfg.RenewBefore.Duration, cfg.Duration.Duration)
+	}
+
+	for i, revision := range cfg.IstioRevisions {
+		if revision == "" {
+			return fmt.Errorf("istioRevisions[%d] must not be empty", i)
+		}
+	}
+
+	if !validKeyAlgorithms[cfg.CMKeyAlgorithm] {
+		return fmt.Errorf("keyAlgorithm must be one of RSA, ECDSA or Ed25519, got %q", cfg.CMKeyAlgorithm)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// IssuerChangeNotifier is implemented by whatever component decides which issuer istio-csr should be
+// using, and notifies subscribers whenever that decision changes.
+type IssuerChangeNotifier interface {
+	// InitialIssuer returns the issuer that was resolved when istio-csr started up, or nil if none could
+	// be resolved.
+	InitialIssuer() *cmmeta.ObjectReference
+
+	// SubscribeIssuerChange returns a channel which receives a value every time the issuer istio-csr
+	// should be using changes. A nil value indicates the issuer could not be resolved.
+	SubscribeIssuerChange() <-chan *cmmeta.ObjectReference
+
+	// ReportInvalidIssuer is called by a subscriber when an issuer it received from
+	// SubscribeIssuerChange fails validation (for example, its Kind or Group isn't allowlisted), so the
+	// notifier has the opportunity to log, retry resolving a different issuer, or otherwise surface the
+	// misconfiguration itself.
+	ReportInvalidIssuer(err error)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"context"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types written onto the managed Certificate by updateCertConditions, in addition to the
+// conditions cert-manager's own certificate controller maintains.
+const (
+	// ConditionIssuerResolved is set to True, naming the issuer in the Certificate's spec, after each
+	// successful reconcile. It is never set to False: a reconcile with no issuer exits before writing
+	// conditions.
+	ConditionIssuerResolved cmapi.CertificateConditionType = "IssuerResolved"
+
+	// ConditionSpecSynced is set to True after each successful reconcile has applied the desired spec to
+	// the Certificate. It is never set to False.
+	ConditionSpecSynced cmapi.CertificateConditionType = "SpecSynced"
+)
+
+// updateCertConditions writes the provisioner's IssuerResolved and SpecSynced conditions onto cert, sets
+// the Reason and Message of the Certificate's Ready condition while keeping the status cert-manager
+// reported (adding Ready=Unknown if there is none yet), and writes the Certificate's status with
+// UpdateStatus. It's a best-effort operation: a failure here doesn't fail the reconcile, since the
+// cert itself has already been issued/updated successfully by this point.
+func (dicp *DynamicIstiodCertProvisioner) updateCertConditions(ctx context.Context, cert *cmapi.Certificate) error {
+	setCertificateCondition(cert, ConditionIssuerResolved, cmmeta.ConditionTrue, "IssuerSet", fmt.Sprintf("using issuer %s/%s", cert.Spec.IssuerRef.Kind, cert.Spec.IssuerRef.Name))
+	setCertificateCondition(cert, ConditionSpecSynced, cmmeta.ConditionTrue, "SpecApplied", "the Certificate's spec matches the desired istiod cert spec")
+	setCertificateCondition(cert, cmapi.CertificateConditionReady, readyConditionStatus(cert), "ObservedFromCertManager", "mirrors cert-manager's own Ready condition for this Certificate")
+
+	_, err := dicp.certManagerClient.UpdateStatus(ctx, cert, metav1.UpdateOptions{})
+	return err
+}
+
+// readyConditionStatus returns cert-manager's own Ready condition status for cert, or ConditionUnknown if
+// it hasn't reported one yet.
+func readyConditionStatus(cert *cmapi.Certificate) cmmeta.ConditionStatus {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == cmapi.CertificateConditionReady {
+			return cond.Status
+		}
+	}
+
+	return cmmeta.ConditionUnknown
+}
+
+// setCertificateCondition sets or updates a condition of the given type on cert.Status.Conditions,
+// bumping LastTransitionTime only when the status actually changes.
+func setCertificateCondition(cert *cmapi.Certificate, condType cmapi.CertificateConditionType, status cmmeta.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+
+	for i, cond := range cert.Status.Conditions {
+		if cond.Type != condType {
+			continue
+		}
+
+		cert.Status.Conditions[i].Reason = reason
+		cert.Status.Conditions[i].Message = message
+
+		if cond.Status != status {
+			cert.Status.Conditions[i].Status = status
+			cert.Status.Conditions[i].LastTransitionTime = &now
+		}
+
+		return
+	}
+
+	cert.Status.Conditions = append(cert.Status.Conditions, cmapi.CertificateCondition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &now,
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the file-based configuration for the istiod cert provisioner, converting it into
+// an istiodcert.Options ready to be passed to istiodcert.New.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	configv1alpha1 "github.com/cert-manager/istio-csr/pkg/apis/config/istiodcert/v1alpha1"
+	"github.com/cert-manager/istio-csr/pkg/istiodcert"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	if err := configv1alpha1.AddToScheme(scheme); err != nil {
+		panic(fmt.Sprintf("failed to register istiod cert provisioner configuration scheme: %s", err))
+	}
+}
+
+// Load reads, defaults and validates the istiod cert provisioner configuration file at path, returning the
+// istiodcert.Options it describes.
+func Load(path string) (istiodcert.Options, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return istiodcert.Options{}, fmt.Errorf("failed to read istiod cert provisioner config file %q: %s", path, err)
+	}
+
+	cfg := &configv1alpha1.IstiodCertProvisionerConfiguration{}
+	if _, _, err := codecs.UniversalDecoder(configv1alpha1.SchemeGroupVersion).Decode(data, nil, cfg); err != nil {
+		return istiodcert.Options{}, fmt.Errorf("failed to decode istiod cert provisioner config file %q: %s", path, err)
+	}
+
+	configv1alpha1.SetDefaults_IstiodCertProvisionerConfiguration(cfg)
+
+	if err := configv1alpha1.ValidateIstiodCertProvisionerConfiguration(cfg); err != nil {
+		return istiodcert.Options{}, fmt.Errorf("invalid istiod cert provisioner config file %q: %s", path, err)
+	}
+
+	return toOptions(cfg), nil
+}
+
+// toOptions converts a validated, defaulted IstiodCertProvisionerConfiguration into istiodcert.Options.
+func toOptions(cfg *configv1alpha1.IstiodCertProvisionerConfiguration) istiodcert.Options {
+	return istiodcert.Options{
+		CertificateName:      cfg.CertificateName,
+		CertificateNamespace: cfg.CertificateNamespace,
+		Duration:             cfg.Duration.Duration,
+		RenewBefore:          cfg.RenewBefore.Duration,
+		IstioRevisions:       cfg.IstioRevisions,
+		AdditionalDNSNames:   cfg.AdditionalDNSNames,
+		CMKeyAlgorithm:       cmapi.PrivateKeyAlgorithm(cfg.CMKeyAlgorithm),
+		KeySize:              cfg.KeySize,
+		PerRevisionCerts:     cfg.PerRevisionCerts,
+		AllowedIssuerKinds:   cfg.AllowedIssuerKinds,
+		AllowedIssuerGroups:  cfg.AllowedIssuerGroups,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-logr/logr"
+
+	"github.com/cert-manager/istio-csr/pkg/istiodcert"
+)
+
+// Reloader watches for SIGHUP and, when received, reloads the istiod cert provisioner configuration file
+// from disk, pushing the new Options onto its channel so that a running DynamicIstiodCertProvisioner can
+// pick up the change without a restart.
+type Reloader struct {
+	log  logr.Logger
+	path string
+
+	optsChan chan istiodcert.Options
+}
+
+// NewReloader returns a Reloader which reloads the configuration file at path whenever it receives
+// SIGHUP.
+func NewReloader(log logr.Logger, path string) *Reloader {
+	return &Reloader{
+		log:      log,
+		path:     path,
+		optsChan: make(chan istiodcert.Options),
+	}
+}
+
+// OptsChan returns the channel new Options are sent on after a reload. Intended to be passed to
+// istiodcert.New.
+func (r *Reloader) OptsChan() <-chan istiodcert.Options {
+	return r.optsChan
+}
+
+// Start blocks, reloading the configuration file and sending the result on OptsChan every time SIGHUP is
+// received, until ctx is cancelled.
+func (r *Reloader) Start(ctx context.Context) error {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-sigChan:
+			r.log.Info("received SIGHUP, reloading istiod cert provisioner configuration", "path", r.path)
+
+			opts, err := Load(r.path)
+			if err != nil {
+				r.log.Error(err, "failed to reload istiod cert provisioner configuration, keeping existing config", "path", r.path)
+				continue
+			}
+
+			select {
+			case r.optsChan <- opts:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
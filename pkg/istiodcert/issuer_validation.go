@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"fmt"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// IssuerValidationCode identifies, like an exit code, the specific reason an IssuerRef was rejected. It
+// lets callers (e.g. a readiness check) distinguish a misconfigured issuer allowlist from other kinds of
+// failure.
+type IssuerValidationCode string
+
+const (
+	// IssuerValidationCodeDisallowedKind means the IssuerRef's Kind isn't in Options.AllowedIssuerKinds.
+	IssuerValidationCodeDisallowedKind IssuerValidationCode = "DisallowedIssuerKind"
+
+	// IssuerValidationCodeDisallowedGroup means the IssuerRef's Group isn't in Options.AllowedIssuerGroups.
+	IssuerValidationCodeDisallowedGroup IssuerValidationCode = "DisallowedIssuerGroup"
+)
+
+// IssuerValidationError is returned when an issuer change is rejected because its Kind or Group isn't
+// allowlisted by Options.AllowedIssuerKinds / Options.AllowedIssuerGroups.
+type IssuerValidationError struct {
+	Code    IssuerValidationCode
+	Message string
+}
+
+func (e *IssuerValidationError) Error() string {
+	return e.Message
+}
+
+// validateIssuerRef checks issuerRef's Kind and Group against the configured allowlists, returning an
+// *IssuerValidationError if either is disallowed. A nil issuerRef is always considered valid. An empty
+// allowedGroups list allows any Group, and an empty allowedKinds list falls back to
+// DefaultAllowedIssuerKinds; the Kind is checked in every case.
+func validateIssuerRef(issuerRef *cmmeta.ObjectReference, allowedKinds, allowedGroups []string) error {
+	if issuerRef == nil {
+		return nil
+	}
+
+	if len(allowedKinds) == 0 {
+		allowedKinds = DefaultAllowedIssuerKinds
+	}
+
+	if !stringSliceContains(allowedKinds, issuerRef.Kind) {
+		return &IssuerValidationError{
+			Code:    IssuerValidationCodeDisallowedKind,
+			Message: fmt.Sprintf("issuer kind %q is not in the allowed list %v", issuerRef.Kind, allowedKinds),
+		}
+	}
+
+	if len(allowedGroups) > 0 && !stringSliceContains(allowedGroups, issuerRef.Group) {
+		return &IssuerValidationError{
+			Code:    IssuerValidationCodeDisallowedGroup,
+			Message: fmt.Sprintf("issuer group %q is not in the allowed list %v", issuerRef.Group, allowedGroups),
+		}
+	}
+
+	return nil
+}
+
+func stringSliceContains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"errors"
+	"testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestValidateIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		issuerRef     *cmmeta.ObjectReference
+		allowedKinds  []string
+		allowedGroups []string
+		wantCode      IssuerValidationCode
+	}{
+		"nil issuerRef is always valid": {
+			issuerRef:     nil,
+			allowedKinds:  []string{"Issuer"},
+			allowedGroups: []string{"cert-manager.io"},
+		},
+		"allowed kind and group": {
+			issuerRef:     &cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"},
+			allowedKinds:  []string{"Issuer", "ClusterIssuer"},
+			allowedGroups: []string{"cert-manager.io"},
+		},
+		"disallowed kind": {
+			issuerRef:     &cmmeta.ObjectReference{Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			allowedKinds:  []string{"Issuer"},
+			allowedGroups: []string{"cert-manager.io"},
+			wantCode:      IssuerValidationCodeDisallowedKind,
+		},
+		"disallowed group": {
+			issuerRef:     &cmmeta.ObjectReference{Kind: "Issuer", Group: "other.io"},
+			allowedKinds:  []string{"Issuer"},
+			allowedGroups: []string{"cert-manager.io"},
+			wantCode:      IssuerValidationCodeDisallowedGroup,
+		},
+		"empty allowedGroups allows any group": {
+			issuerRef:     &cmmeta.ObjectReference{Kind: "Issuer", Group: "other.io"},
+			allowedKinds:  []string{"Issuer"},
+			allowedGroups: nil,
+		},
+		"empty allowedKinds falls back to DefaultAllowedIssuerKinds": {
+			issuerRef:     &cmmeta.ObjectReference{Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			allowedKinds:  nil,
+			allowedGroups: []string{"cert-manager.io"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateIssuerRef(test.issuerRef, test.allowedKinds, test.allowedGroups)
+
+			if test.wantCode == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error code %q, got nil error", test.wantCode)
+			}
+
+			var validationErr *IssuerValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected an *IssuerValidationError, got: %T", err)
+			}
+
+			if validationErr.Code != test.wantCode {
+				t.Errorf("expected error code %q, got %q", test.wantCode, validationErr.Code)
+			}
+		})
+	}
+}
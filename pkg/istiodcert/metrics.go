@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const metricsNamespace = "istio_csr"
+const metricsSubsystem = "istiod_cert"
+
+// Metrics holds the Prometheus collectors describing the state of the istiod cert, as seen by
+// DynamicIstiodCertProvisioner. Callers register these against their own registry, typically the one
+// backing istio-csr's existing metrics endpoint.
+//
+// secondsUntilExpiry and ready are labelled by cert_name rather than being plain Gauges, since
+// Options.PerRevisionCerts means there can be more than one istiod Certificate to track at once.
+type Metrics struct {
+	secondsUntilExpiry   *prometheus.GaugeVec
+	issuerChangesTotal   prometheus.Counter
+	reconcileErrorsTotal *prometheus.CounterVec
+	ready                *prometheus.GaugeVec
+}
+
+// NewMetrics constructs the Prometheus collectors for the istiod cert provisioner. They start unset/zero
+// until the first reconcile or expiry check populates them.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		secondsUntilExpiry: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "seconds_until_expiry",
+			Help:      "Number of seconds until the istiod certificate currently in the backing Secret expires.",
+		}, []string{"cert_name"}),
+		issuerChangesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "issuer_changes_total",
+			Help:      "Total number of times the issuer used for the istiod certificate has changed.",
+		}),
+		reconcileErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reconcile_errors_total",
+			Help:      "Total number of errors encountered reconciling the istiod certificate, by reason.",
+		}, []string{"reason"}),
+		ready: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "ready",
+			Help:      "Whether the istiod certificate is currently Ready, as last observed by a reconcile (1) or not (0).",
+		}, []string{"cert_name"}),
+	}
+}
+
+// Collectors returns every collector that makes up Metrics, ready to be passed to a Prometheus
+// registerer's MustRegister.
+func (m *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.secondsUntilExpiry,
+		m.issuerChangesTotal,
+		m.reconcileErrorsTotal,
+		m.ready,
+	}
+}
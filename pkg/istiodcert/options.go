@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Options are the configurable options for the dynamic istiod cert provisioner.
+type Options struct {
+	// CertificateName is the name of the Certificate resource used to manage the istiod cert.
+	CertificateName string
+
+	// CertificateNamespace is the namespace the istiod Certificate resource lives in.
+	CertificateNamespace string
+
+	// Duration is the requested duration of the istiod certificate.
+	Duration time.Duration
+
+	// RenewBefore is the period of time before the certificate's expiry at which it should be renewed.
+	RenewBefore time.Duration
+
+	// IstioRevisions is the list of istio revisions to provision the istiod cert for.
+	IstioRevisions []string
+
+	// AdditionalDNSNames are additional DNS SANs to request on the istiod cert, on top of those derived
+	// from IstioRevisions.
+	AdditionalDNSNames []string
+
+	// CMKeyAlgorithm is the private key algorithm to request from cert-manager for the istiod cert.
+	CMKeyAlgorithm cmapi.PrivateKeyAlgorithm
+
+	// KeySize is the private key size to request from cert-manager for the istiod cert.
+	KeySize int
+
+	// PerRevisionCerts, when true, provisions one Certificate (and backing Secret) per configured istio
+	// revision instead of a single Certificate covering every revision's DNS name. This allows each
+	// revision to be rotated independently, and lets the common name of each cert match its revision's DNS
+	// SAN. AdditionalDNSNames are added to every revision's cert.
+	PerRevisionCerts bool
+
+	// AllowedIssuerKinds is the list of cert-manager issuer Kinds istio-csr is permitted to use for the
+	// istiod cert. An incoming issuer change whose Kind isn't in this list is rejected. Defaults to
+	// "Issuer" and "ClusterIssuer" if left unset.
+	AllowedIssuerKinds []string
+
+	// AllowedIssuerGroups is the list of cert-manager issuer API groups istio-csr is permitted to use for
+	// the istiod cert. An incoming issuer change whose Group isn't in this list is rejected. An empty list
+	// allows any group.
+	AllowedIssuerGroups []string
+}
+
+// DefaultAllowedIssuerKinds are the issuer Kinds used when Options.AllowedIssuerKinds is left unset.
+var DefaultAllowedIssuerKinds = []string{"Issuer", "ClusterIssuer"}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"context"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// RevisionLabel is set on every per-revision istiod Certificate created when Options.PerRevisionCerts is
+// enabled, identifying which istio revision the Certificate and its backing Secret belong to. It's used to
+// find the set of Certificates this controller owns when garbage-collecting those for revisions that are
+// no longer configured.
+const RevisionLabel = "istio-csr.cert-manager.io/revision"
+
+// istioRevisionsOrDefault returns revisions, or a single "default" revision if revisions is empty.
+func istioRevisionsOrDefault(revisions []string) []string {
+	if len(revisions) == 0 {
+		return []string{"default"}
+	}
+
+	return revisions
+}
+
+// revisionCertResult is the outcome of reconciling a single istio revision's Certificate, returned by
+// reconcileRevisionCerts so that the caller can feed it into recordReconcileOutcome/updateCertConditions
+// the same way it does for the single-Certificate reconcile path.
+type revisionCertResult struct {
+	Revision string
+	Cert     *cmapi.Certificate
+	Err      error
+}
+
+// reconcileRevisionCerts provisions one Certificate (and backing Secret) per configured istio revision,
+// each with that revision's DNS name (and no other revision's) as its CommonName and first SAN, followed by
+// any Options.AdditionalDNSNames, so that revisions can be rotated independently of one another. It also removes any revision Certificate previously created by
+// this controller for a revision that's since been removed from Options.IstioRevisions, and services any
+// pending refresh-certificates annotation on each revision's Certificate.
+func (dicp *DynamicIstiodCertProvisioner) reconcileRevisionCerts(ctx context.Context, namespace, spiffeID string) ([]revisionCertResult, error) {
+	revisions := istioRevisionsOrDefault(dicp.opts.IstioRevisions)
+
+	desired := make(map[string]bool, len(revisions))
+	results := make([]revisionCertResult, 0, len(revisions))
+
+	for _, revision := range revisions {
+		desired[revision] = true
+
+		cert, err := dicp.applyRevisionCert(ctx, namespace, revision, spiffeID)
+		if err == nil {
+			err = dicp.reconcileRefreshRequest(ctx, cert)
+		}
+
+		results = append(results, revisionCertResult{Revision: revision, Cert: cert, Err: err})
+	}
+
+	if err := dicp.pruneRevisionCerts(ctx, desired); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// applyRevisionCert creates or updates the Certificate for a single istio revision, returning the
+// Certificate as last observed/updated by the API server.
+func (dicp *DynamicIstiodCertProvisioner) applyRevisionCert(ctx context.Context, namespace, revision, spiffeID string) (*cmapi.Certificate, error) {
+	name := revisionCertName(revision)
+	dnsName := dnsNameForRevision(namespace, revision)
+
+	dnsNames := []string{dnsName}
+	if len(dicp.opts.AdditionalDNSNames) > 0 {
+		dnsNames = append(dnsNames, dicp.opts.AdditionalDNSNames...)
+	}
+
+	spec := cmapi.CertificateSpec{
+		CommonName:  dnsName,
+		DNSNames:    dnsNames,
+		URIs:        []string{spiffeID},
+		SecretName:  name,
+		Duration:    &metav1.Duration{Duration: dicp.opts.Duration},
+		RenewBefore: &metav1.Duration{Duration: dicp.opts.RenewBefore},
+		PrivateKey: &cmapi.CertificatePrivateKey{
+			RotationPolicy: cmapi.RotationPolicyAlways,
+			Algorithm:      dicp.opts.CMKeyAlgorithm,
+			Size:           dicp.opts.KeySize,
+		},
+		RevisionHistoryLimit: ptr.To(int32(1)),
+		IssuerRef:            *dicp.issuerRef,
+	}
+
+	cert, err := dicp.certManagerClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to fetch revision cert %q: %s", name, err)
+		}
+
+		newCert := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{RevisionLabel: revision},
+			},
+			Spec: spec,
+		}
+
+		return dicp.certManagerClient.Create(ctx, newCert, metav1.CreateOptions{})
+	}
+
+	if cert.Labels == nil {
+		cert.Labels = map[string]string{}
+	}
+	cert.Labels[RevisionLabel] = revision
+	cert.Spec = spec
+
+	return dicp.certManagerClient.Update(ctx, cert, metav1.UpdateOptions{})
+}
+
+// pruneRevisionCerts deletes any Certificate owned by this controller (identified by RevisionLabel) whose
+// revision is no longer in the desired set.
+func (dicp *DynamicIstiodCertProvisioner) pruneRevisionCerts(ctx context.Context, desired map[string]bool) error {
+	certs, err := dicp.certManagerClient.List(ctx, metav1.ListOptions{
+		LabelSelector: RevisionLabel,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list revision certs: %s", err)
+	}
+
+	for i := range certs.Items {
+		cert := &certs.Items[i]
+
+		revision, ok := cert.Labels[RevisionLabel]
+		if !ok || desired[revision] {
+			continue
+		}
+
+		dicp.log.Info("removing istiod cert for revision no longer configured", "cert_name", cert.Name, "cert_namespace", cert.Namespace, "revision", revision)
+
+		if err := dicp.certManagerClient.Delete(ctx, cert.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete revision cert %q: %s", cert.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// revisionCertName returns the name of the Certificate (and its backing Secret) for a single istio
+// revision.
+func revisionCertName(revision string) string {
+	return fmt.Sprintf("istiod-%s-tls", revision)
+}
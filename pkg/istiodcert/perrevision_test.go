@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testRevisionCertificate(revision string) *cmapi.Certificate {
+	return &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionCertName(revision),
+			Namespace: testCertNamespace,
+			Labels:    map[string]string{RevisionLabel: revision},
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: revisionCertName(revision),
+		},
+	}
+}
+
+func TestApplyRevisionCert_Creates(t *testing.T) {
+	dicp := newTestProvisioner(t, nil, nil)
+	dicp.issuerRef = &cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}
+
+	cert, err := dicp.applyRevisionCert(context.Background(), testCertNamespace, "default", "spiffe://cluster.local/ns/istio-system/sa/istiod-service-account")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cert.Name != revisionCertName("default") {
+		t.Errorf("expected cert name %q, got %q", revisionCertName("default"), cert.Name)
+	}
+
+	if cert.Labels[RevisionLabel] != "default" {
+		t.Errorf("expected revision label %q, got %q", "default", cert.Labels[RevisionLabel])
+	}
+}
+
+func TestApplyRevisionCert_Updates(t *testing.T) {
+	existing := testRevisionCertificate("canary")
+	existing.Labels = nil // simulate a cert that predates RevisionLabel being set
+
+	dicp := newTestProvisioner(t, []runtime.Object{existing}, nil)
+	dicp.issuerRef = &cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}
+
+	cert, err := dicp.applyRevisionCert(context.Background(), testCertNamespace, "canary", "spiffe://cluster.local/ns/istio-system/sa/istiod-service-account")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cert.Labels[RevisionLabel] != "canary" {
+		t.Errorf("expected RevisionLabel to be backfilled on update, got %q", cert.Labels[RevisionLabel])
+	}
+
+	if cert.Spec.CommonName != dnsNameForRevision(testCertNamespace, "canary") {
+		t.Errorf("expected CommonName %q, got %q", dnsNameForRevision(testCertNamespace, "canary"), cert.Spec.CommonName)
+	}
+}
+
+func TestPruneRevisionCerts(t *testing.T) {
+	keep := testRevisionCertificate("default")
+	remove := testRevisionCertificate("canary")
+
+	dicp := newTestProvisioner(t, []runtime.Object{keep, remove}, nil)
+
+	if err := dicp.pruneRevisionCerts(context.Background(), map[string]bool{"default": true}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := dicp.certManagerClient.Get(context.Background(), keep.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected cert for still-configured revision to be kept, got: %s", err)
+	}
+
+	if _, err := dicp.certManagerClient.Get(context.Background(), remove.Name, metav1.GetOptions{}); err == nil {
+		t.Error("expected cert for removed revision to be deleted")
+	}
+}
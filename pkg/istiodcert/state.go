@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// State is a snapshot of the provisioner's view of the istiod cert, the "state of the world" as of the
+// last reconcile. It's updated by Reconcile, by accepted issuer changes and by the periodic expiry check in
+// Start, and a copy of it is returned by State. The conditions written onto the managed Certificate and the
+// Prometheus metrics exposed by Metrics are updated alongside it, not computed from it.
+type State struct {
+	// IssuerRef is the issuer the provisioner is currently configured to use.
+	IssuerRef *cmmeta.ObjectReference
+
+	// DesiredSpec is the CertificateSpec the provisioner most recently computed and applied.
+	DesiredSpec *cmapi.CertificateSpec
+
+	// ObservedCert is the Certificate resource as last observed by Reconcile.
+	ObservedCert *cmapi.Certificate
+
+	// LastReconcileError is the error returned by the most recent Reconcile call, or nil if it succeeded.
+	LastReconcileError error
+
+	// LastIssuanceTime is the time of the most recent successful reconcile which applied a new spec.
+	LastIssuanceTime *time.Time
+
+	// SecretNotAfter is the expiry time of the certificate currently stored in the backing Secret, last
+	// refreshed either by a reconcile or by the periodic expiry-check ticker in Start.
+	SecretNotAfter *time.Time
+}
+
+// State returns a copy of the provisioner's current state of the world.
+func (dicp *DynamicIstiodCertProvisioner) State() State {
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
+
+	return dicp.worldState
+}
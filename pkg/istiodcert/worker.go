@@ -18,18 +18,26 @@ package istiodcert
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmversioned "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	kubetypedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -44,46 +52,116 @@ import (
 	"github.com/cert-manager/istio-csr/pkg/certmanager"
 )
 
+const (
+	// RefreshCertificateAnnotation, when set to "true" on the managed istiod Certificate, requests an
+	// immediate forced re-issuance of the istiod cert, regardless of RenewBefore. This is intended for
+	// emergency key rotation (e.g. a suspected key compromise) without having to delete the backing
+	// Secret out-of-band.
+	RefreshCertificateAnnotation = "istio-csr.cert-manager.io/refresh-certificates"
+
+	// RefreshCertificateStatusAnnotation records the outcome of the most recently requested refresh, so
+	// that an operator can poll for completion. Its value is one of RefreshStatusInProgress,
+	// RefreshStatusDone or RefreshStatusFailed.
+	RefreshCertificateStatusAnnotation = "istio-csr.cert-manager.io/refresh-certificates-status"
+
+	// refreshRequestedAtAnnotation records the time at which the in-progress refresh was started, so
+	// that a subsequent reconcile can tell whether the backing Secret has rotated since.
+	refreshRequestedAtAnnotation = "istio-csr.cert-manager.io/refresh-certificates-requested-at"
+)
+
+// Values for RefreshCertificateStatusAnnotation.
+const (
+	RefreshStatusInProgress = "in-progress"
+	RefreshStatusDone       = "done"
+	RefreshStatusFailed     = "failed"
+)
+
+const refreshRequestValue = "true"
+
+// defaultSecretName is the backing Secret name used for the single Certificate managed when
+// Options.PerRevisionCerts is false. It's shared between Reconcile (which sets it on the Certificate spec)
+// and the Secret watch in AddControllersToManager (which needs to recognise it to map a Secret event back to
+// the right Certificate).
+const defaultSecretName = "istiod-tls"
+
 // DynamicIstiodCertProvisioner is both:
 // 1. A controller-runtime controller for watching the dynamic istiod cert and keeping it updated
 // 2. A wrapper around ctrlmgr.Runnable for listening for issuer changes and notifying the certificate controller
 type DynamicIstiodCertProvisioner struct {
 	log               logr.Logger
 	certManagerClient cmclient.CertificateInterface
+	secretClient      kubetypedcorev1.SecretInterface
 	opts              Options
 
 	initialIssuerRef *cmmeta.ObjectReference
 	issuerRef        *cmmeta.ObjectReference
 
-	issuerRefMutex sync.Mutex
+	// lastIssuerValidationErr records the error from the most recent rejected issuer change, if any, so
+	// that it can be surfaced via Check for a readiness probe.
+	lastIssuerValidationErr error
 
-	issuerChangeChan <-chan *cmmeta.ObjectReference
+	// worldState is the provisioner's "state of the world" snapshot, updated by every reconcile and
+	// exposed via State.
+	worldState State
+
+	// metrics are the Prometheus collectors, updated alongside worldState; see Metrics.
+	metrics *Metrics
+
+	// stateMutex guards issuerRef, opts, lastIssuerValidationErr and worldState, all of which are read
+	// during Reconcile/Check/State and written asynchronously in response to issuer changes or
+	// configuration reloads.
+	stateMutex sync.Mutex
+
+	issuerChangeNotifier certmanager.IssuerChangeNotifier
+	issuerChangeChan     <-chan *cmmeta.ObjectReference
+
+	// recorder emits Kubernetes events against the managed Certificate, e.g. when an issuer change is
+	// rejected by the allowlist. It's set by AddControllersToManager once a manager is available.
+	recorder record.EventRecorder
+
+	// optsChan, when non-nil, delivers a new Options value every time the on-disk configuration is
+	// reloaded (e.g. on SIGHUP), causing a reconcile of the dynamic istiod cert with the updated spec.
+	optsChan <-chan Options
 
 	reconcileChan chan event.GenericEvent
 
 	trustDomain string
 }
 
-// New creates a DynamicIstiodCertProvisioner, ready to be added to a controller manager
-func New(log logr.Logger, restConfig *rest.Config, opts Options, issuerChangeNotifier certmanager.IssuerChangeNotifier, trustDomain string) (*DynamicIstiodCertProvisioner, error) {
+// New creates a DynamicIstiodCertProvisioner, ready to be added to a controller manager. optsChan may be
+// nil, in which case Options can never be changed without a restart.
+func New(log logr.Logger, restConfig *rest.Config, opts Options, issuerChangeNotifier certmanager.IssuerChangeNotifier, optsChan <-chan Options, trustDomain string) (*DynamicIstiodCertProvisioner, error) {
 	cmClient, err := cmversioned.NewForConfig(restConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build cert-manager client: %s", err)
 	}
 
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes client: %s", err)
+	}
+
 	initialIssuerRef := issuerChangeNotifier.InitialIssuer()
 
 	return &DynamicIstiodCertProvisioner{
 		log:               log,
 		certManagerClient: cmClient.CertmanagerV1().Certificates(opts.CertificateNamespace),
+		secretClient:      kubeClient.CoreV1().Secrets(opts.CertificateNamespace),
 		opts:              opts,
 
 		initialIssuerRef: initialIssuerRef,
 		issuerRef:        initialIssuerRef,
 
-		issuerRefMutex: sync.Mutex{},
+		worldState: State{IssuerRef: initialIssuerRef},
+
+		metrics: NewMetrics(),
 
-		issuerChangeChan: issuerChangeNotifier.SubscribeIssuerChange(),
+		stateMutex: sync.Mutex{},
+
+		issuerChangeNotifier: issuerChangeNotifier,
+		issuerChangeChan:     issuerChangeNotifier.SubscribeIssuerChange(),
+
+		optsChan: optsChan,
 
 		reconcileChan: make(chan event.GenericEvent),
 
@@ -95,6 +173,11 @@ func New(log logr.Logger, restConfig *rest.Config, opts Options, issuerChangeNot
 // It waits for a notification of an issuer change, and when it gets one it
 // triggers reconciliation of the dynamic istiod cert.
 func (dicp *DynamicIstiodCertProvisioner) Start(ctx context.Context) error {
+	// expiryTicker keeps the seconds_until_expiry metric fresh even when reconciles aren't happening,
+	// e.g. because the issuer is unchanged and renewal isn't yet due.
+	expiryTicker := time.NewTicker(30 * time.Second)
+	defer expiryTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -103,10 +186,105 @@ func (dicp *DynamicIstiodCertProvisioner) Start(ctx context.Context) error {
 
 		case newIssuer := <-dicp.issuerChangeChan:
 			dicp.handleNewIssuer(newIssuer)
+
+		case newOpts, ok := <-dicp.optsChan:
+			if !ok {
+				// a nil optsChan blocks forever here, so this only fires if a real channel was
+				// closed; disable this case to avoid spinning
+				dicp.optsChan = nil
+				continue
+			}
+			dicp.handleNewOpts(newOpts)
+
+		case <-expiryTicker.C:
+			dicp.refreshExpiryMetric(ctx)
 		}
 	}
 }
 
+// Metrics returns the Prometheus collectors tracking the istiod cert's state, ready to be registered
+// against the metrics registry backing istio-csr's existing metrics endpoint.
+func (dicp *DynamicIstiodCertProvisioner) Metrics() *Metrics {
+	return dicp.metrics
+}
+
+// refreshExpiryMetric re-checks the expiry of the current istiod cert independently of any reconcile, so
+// that seconds_until_expiry stays accurate (and alertable) even between reconciles.
+func (dicp *DynamicIstiodCertProvisioner) refreshExpiryMetric(ctx context.Context) {
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
+
+	if dicp.opts.PerRevisionCerts {
+		for _, revision := range istioRevisionsOrDefault(dicp.opts.IstioRevisions) {
+			name := revisionCertName(revision)
+
+			cert, err := dicp.certManagerClient.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					dicp.log.Error(err, "failed to refresh istiod cert expiry metric", "cert_name", name)
+				}
+				continue
+			}
+
+			dicp.recordCertExpiry(cert)
+		}
+		return
+	}
+
+	cert, err := dicp.certManagerClient.Get(ctx, dicp.opts.CertificateName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			dicp.log.Error(err, "failed to refresh istiod cert expiry metric")
+		}
+		return
+	}
+
+	dicp.recordCertExpiry(cert)
+}
+
+// recordCertExpiry updates worldState.SecretNotAfter and the seconds_until_expiry gauge from cert's
+// reported NotAfter. Must be called with stateMutex held.
+func (dicp *DynamicIstiodCertProvisioner) recordCertExpiry(cert *cmapi.Certificate) {
+	if cert.Status.NotAfter == nil {
+		return
+	}
+
+	notAfter := cert.Status.NotAfter.Time
+	dicp.worldState.SecretNotAfter = &notAfter
+	dicp.metrics.secondsUntilExpiry.WithLabelValues(cert.Name).Set(time.Until(notAfter).Seconds())
+}
+
+// handleNewOpts is called when a reloaded configuration file produces a new Options value (see
+// pkg/istiodcert/config.Reloader). It replaces the active Options and triggers a reconcile of the dynamic
+// istiod cert so the new spec takes effect immediately.
+//
+// PerRevisionCerts is rejected if it differs from the running value: flipping it live would leave behind
+// the previous mode's Certificates and Secrets (a single istiod cert's resources aren't cleaned up when
+// switching to per-revision certs, and vice versa), so changing it requires a restart instead.
+func (dicp *DynamicIstiodCertProvisioner) handleNewOpts(opts Options) {
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
+
+	if opts.PerRevisionCerts != dicp.opts.PerRevisionCerts {
+		err := fmt.Errorf("perRevisionCerts changed from %t to %t", dicp.opts.PerRevisionCerts, opts.PerRevisionCerts)
+		dicp.log.Error(err, "ignoring reloaded istiod cert provisioner configuration; perRevisionCerts cannot be changed without restarting istio-csr", "cert_name", dicp.opts.CertificateName, "cert_namespace", dicp.opts.CertificateNamespace)
+		return
+	}
+
+	dicp.opts = opts
+
+	dicp.log.Info("reloaded istiod cert provisioner configuration, triggering reconciliation", "cert_name", dicp.opts.CertificateName, "cert_namespace", dicp.opts.CertificateNamespace)
+
+	dicp.reconcileChan <- event.GenericEvent{
+		Object: &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      dicp.opts.CertificateName,
+				Namespace: dicp.opts.CertificateNamespace,
+			},
+		},
+	}
+}
+
 // NeedLeaderElection returns true, because the DynamicIstiodCertProvisioner should only run in one pod
 // to avoid multiple pods trying to change the same certificate.
 func (dicp *DynamicIstiodCertProvisioner) NeedLeaderElection() bool {
@@ -114,8 +292,8 @@ func (dicp *DynamicIstiodCertProvisioner) NeedLeaderElection() bool {
 }
 
 func (dicp *DynamicIstiodCertProvisioner) handleNewIssuer(issuerRef *cmmeta.ObjectReference) {
-	dicp.issuerRefMutex.Lock()
-	defer dicp.issuerRefMutex.Unlock()
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
 
 	if issuerRef == nil && dicp.initialIssuerRef != nil {
 		// don't blank out the issuer if there's an initial ref; use that instead
@@ -123,7 +301,29 @@ func (dicp *DynamicIstiodCertProvisioner) handleNewIssuer(issuerRef *cmmeta.Obje
 		return
 	}
 
+	if err := validateIssuerRef(issuerRef, dicp.opts.AllowedIssuerKinds, dicp.opts.AllowedIssuerGroups); err != nil {
+		dicp.log.Error(err, "rejecting issuer change", "issuer-name", issuerRef.Name, "issuer-kind", issuerRef.Kind, "issuer-group", issuerRef.Group)
+
+		dicp.lastIssuerValidationErr = err
+		dicp.issuerChangeNotifier.ReportInvalidIssuer(err)
+
+		if dicp.recorder != nil {
+			dicp.recorder.Eventf(&cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dicp.opts.CertificateName,
+					Namespace: dicp.opts.CertificateNamespace,
+				},
+			}, corev1.EventTypeWarning, "IssuerRefDisallowed", err.Error())
+		}
+
+		// deliberately leave dicp.issuerRef untouched
+		return
+	}
+
+	dicp.lastIssuerValidationErr = nil
 	dicp.issuerRef = issuerRef
+	dicp.worldState.IssuerRef = issuerRef
+	dicp.metrics.issuerChangesTotal.Inc()
 
 	dicp.log.Info("triggering reconciliation of istiod cert after issuer change", "cert_name", dicp.opts.CertificateName, "cert_namespace", dicp.opts.CertificateNamespace)
 
@@ -137,25 +337,116 @@ func (dicp *DynamicIstiodCertProvisioner) handleNewIssuer(issuerRef *cmmeta.Obje
 	}
 }
 
+// Check implements a controller-runtime healthz.Checker, returning the most recent issuer validation
+// error (if any) so that istio-csr's readiness probe fails while the configured issuer is disallowed,
+// rather than silently continuing to serve certificates signed by the last-known-good issuer.
+func (dicp *DynamicIstiodCertProvisioner) Check(_ *http.Request) error {
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
+
+	return dicp.lastIssuerValidationErr
+}
+
+// snapshotOpts returns a copy of the active Options, taking stateMutex. It's used by the predicate and
+// mapping functions registered with the controller-runtime builder in AddControllersToManager: those
+// closures are invoked concurrently by the controller's event handlers for as long as the manager runs, so
+// they must not read dicp.opts directly while handleNewOpts may be replacing it from a config reload.
+func (dicp *DynamicIstiodCertProvisioner) snapshotOpts() Options {
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
+
+	return dicp.opts
+}
+
+// certificateRequestForSecret returns the reconcile.Request for the Certificate owning the given Secret, or
+// nil if the Secret isn't one this controller manages.
+func (dicp *DynamicIstiodCertProvisioner) certificateRequestForSecret(obj client.Object) []reconcile.Request {
+	opts := dicp.snapshotOpts()
+
+	if obj.GetNamespace() != opts.CertificateNamespace {
+		return nil
+	}
+
+	if opts.PerRevisionCerts {
+		for _, revision := range istioRevisionsOrDefault(opts.IstioRevisions) {
+			name := revisionCertName(revision)
+			if obj.GetName() != name {
+				continue
+			}
+
+			// the revision Certificate and its backing Secret share the same name
+			return []reconcile.Request{ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: opts.CertificateNamespace},
+			}}
+		}
+
+		return nil
+	}
+
+	if obj.GetName() != defaultSecretName {
+		return nil
+	}
+
+	return []reconcile.Request{ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      opts.CertificateName,
+			Namespace: opts.CertificateNamespace,
+		},
+	}}
+}
+
 // AddControllersToManager adds controllers to the given manager which:
 // 1. Handle provisioning and updating the dynamic istiod cert
 // 2. Handle listening for updates to the active issuer ref and re-issuing
 func (dicp *DynamicIstiodCertProvisioner) AddControllersToManager(mgr manager.Manager) error {
+	dicp.recorder = mgr.GetEventRecorderFor("istiod-cert-provisioner")
+
 	b := ctrl.NewControllerManagedBy(mgr)
 
 	b.For(
-		new(cmapi.Certificate), builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
-			// Only process one specific cert which was requested
-			return obj.GetName() == dicp.opts.CertificateName && obj.GetNamespace() == dicp.opts.CertificateNamespace
-		})))
-
-	// when the issuer changes, trigger a re-reconciliation
+		new(cmapi.Certificate), builder.WithPredicates(predicate.And(
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				opts := dicp.snapshotOpts()
+
+				if obj.GetNamespace() != opts.CertificateNamespace {
+					return false
+				}
+
+				if opts.PerRevisionCerts {
+					// Process every revision Certificate this controller owns, identified by RevisionLabel
+					_, ok := obj.GetLabels()[RevisionLabel]
+					return ok
+				}
+
+				// Only process the one specific cert which was requested
+				return obj.GetName() == opts.CertificateName
+			}),
+			// Only reconcile on changes to the spec/generation, or to the annotations (so that the
+			// refresh-certificates annotation is picked up); this avoids reconciling on every status update.
+			predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			),
+		)))
+
+	// watch the backing Secret so that a pending refresh transitions from in-progress to done as soon as
+	// cert-manager rotates it, rather than waiting on the next unrelated Certificate reconcile. Only the
+	// Secret(s) this controller actually owns are mapped to a request; any other Secret in the namespace
+	// (including ones unrelated to istiod) is ignored.
+	b.Watches(new(corev1.Secret), handler.EnqueueRequestsFromMapFunc(
+		func(_ context.Context, obj client.Object) []reconcile.Request {
+			return dicp.certificateRequestForSecret(obj)
+		}))
+
+	// when the issuer or configuration changes, trigger a re-reconciliation. The NamespacedName to
+	// reconcile is taken from the event object itself (set by the sender under stateMutex), rather than
+	// re-reading dicp.opts here, since this mapper runs outside of any lock.
 	b.WatchesRawSource(source.Channel(dicp.reconcileChan, handler.EnqueueRequestsFromMapFunc(
-		func(context.Context, client.Object) []reconcile.Request {
+		func(_ context.Context, obj client.Object) []reconcile.Request {
 			return []reconcile.Request{ctrl.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      dicp.opts.CertificateName,
-					Namespace: dicp.opts.CertificateNamespace,
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
 				},
 			}}
 		})))
@@ -174,8 +465,8 @@ func (dicp *DynamicIstiodCertProvisioner) AddControllersToManager(mgr manager.Ma
 }
 
 func (dicp *DynamicIstiodCertProvisioner) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	dicp.issuerRefMutex.Lock()
-	defer dicp.issuerRefMutex.Unlock()
+	dicp.stateMutex.Lock()
+	defer dicp.stateMutex.Unlock()
 
 	if dicp.issuerRef == nil {
 		dicp.log.Info("exiting reconcile of dynamic istiod early; no issuerRef is set")
@@ -186,6 +477,41 @@ func (dicp *DynamicIstiodCertProvisioner) Reconcile(ctx context.Context, req ctr
 
 	spiffeID := fmt.Sprintf("spiffe://%s/ns/%s/sa/istiod-service-account", dicp.trustDomain, req.Namespace)
 
+	if dicp.opts.PerRevisionCerts {
+		results, err := dicp.reconcileRevisionCerts(ctx, req.Namespace, spiffeID)
+
+		for _, result := range results {
+			certName := revisionCertName(result.Revision)
+
+			if result.Err != nil {
+				dicp.recordReconcileOutcome("revision-certs", certName, nil, result.Err)
+				continue
+			}
+
+			if uerr := dicp.updateCertConditions(ctx, result.Cert); uerr != nil {
+				dicp.log.Error(uerr, "failed to update istiod cert conditions", "cert_name", result.Cert.Name, "cert_namespace", result.Cert.Namespace)
+			}
+
+			dicp.recordReconcileOutcome("", certName, result.Cert, nil)
+		}
+
+		if err != nil {
+			dicp.recordReconcileOutcome("revision-certs", "", nil, err)
+		} else {
+			// return the first failed revision's error, so that the request is retried and
+			// LastReconcileError matches what Reconcile returns
+			for _, result := range results {
+				if result.Err != nil {
+					err = result.Err
+					break
+				}
+			}
+			dicp.worldState.LastReconcileError = err
+		}
+
+		return ctrl.Result{}, err
+	}
+
 	commonName, dnsNames := makeDNSNamesFromRevisions(req.Namespace, dicp.opts.IstioRevisions)
 
 	if len(dicp.opts.AdditionalDNSNames) > 0 {
@@ -196,7 +522,7 @@ func (dicp *DynamicIstiodCertProvisioner) Reconcile(ctx context.Context, req ctr
 		CommonName:  commonName,
 		DNSNames:    dnsNames,
 		URIs:        []string{spiffeID},
-		SecretName:  "istiod-tls",
+		SecretName:  defaultSecretName,
 		Duration:    &metav1.Duration{Duration: dicp.opts.Duration},
 		RenewBefore: &metav1.Duration{Duration: dicp.opts.RenewBefore},
 		PrivateKey: &cmapi.CertificatePrivateKey{
@@ -208,11 +534,15 @@ func (dicp *DynamicIstiodCertProvisioner) Reconcile(ctx context.Context, req ctr
 		IssuerRef:            *dicp.issuerRef,
 	}
 
+	dicp.worldState.DesiredSpec = desiredSpec.DeepCopy()
+
 	cert, err := dicp.certManagerClient.Get(ctx, req.Name, metav1.GetOptions{})
 
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, fmt.Errorf("failed to fetch cert: %s", err)
+			err = fmt.Errorf("failed to fetch cert: %s", err)
+			dicp.recordReconcileOutcome("get", req.Name, nil, err)
+			return ctrl.Result{}, err
 		}
 
 		cert := cmapi.Certificate{
@@ -223,14 +553,185 @@ func (dicp *DynamicIstiodCertProvisioner) Reconcile(ctx context.Context, req ctr
 			Spec: desiredSpec,
 		}
 
-		_, err = dicp.certManagerClient.Create(ctx, &cert, metav1.CreateOptions{})
+		created, err := dicp.certManagerClient.Create(ctx, &cert, metav1.CreateOptions{})
+		dicp.recordReconcileOutcome("create", req.Name, created, err)
 		return ctrl.Result{}, err
 	}
 
 	cert.Spec = desiredSpec
 
-	_, err = dicp.certManagerClient.Update(ctx, cert, metav1.UpdateOptions{})
-	return ctrl.Result{}, err
+	cert, err = dicp.certManagerClient.Update(ctx, cert, metav1.UpdateOptions{})
+	if err != nil {
+		dicp.recordReconcileOutcome("update", req.Name, nil, err)
+		return ctrl.Result{}, err
+	}
+
+	if err := dicp.reconcileRefreshRequest(ctx, cert); err != nil {
+		dicp.recordReconcileOutcome("refresh", cert.Name, cert, err)
+		return ctrl.Result{}, err
+	}
+
+	if err := dicp.updateCertConditions(ctx, cert); err != nil {
+		dicp.log.Error(err, "failed to update istiod cert conditions", "cert_name", cert.Name, "cert_namespace", cert.Namespace)
+	}
+
+	dicp.recordReconcileOutcome("", cert.Name, cert, nil)
+	return ctrl.Result{}, nil
+}
+
+// recordReconcileOutcome updates worldState and the Prometheus metrics following a reconcile of the
+// Certificate named certName. reason is a short, stable label describing which step of the reconcile
+// failed (ignored when err is nil); certName may be empty when no single Certificate can be attributed to
+// the failure (e.g. a failure to prune revision Certificates), in which case the ready gauge is left
+// untouched. Must be called with stateMutex held.
+func (dicp *DynamicIstiodCertProvisioner) recordReconcileOutcome(reason, certName string, cert *cmapi.Certificate, err error) {
+	dicp.worldState.LastReconcileError = err
+
+	if err != nil {
+		dicp.metrics.reconcileErrorsTotal.WithLabelValues(reason).Inc()
+		if certName != "" {
+			dicp.metrics.ready.WithLabelValues(certName).Set(0)
+		}
+		return
+	}
+
+	if cert == nil {
+		return
+	}
+
+	dicp.worldState.ObservedCert = cert
+	now := time.Now()
+	dicp.worldState.LastIssuanceTime = &now
+
+	dicp.recordCertExpiry(cert)
+
+	if readyConditionStatus(cert) == cmmeta.ConditionTrue {
+		dicp.metrics.ready.WithLabelValues(certName).Set(1)
+	} else {
+		dicp.metrics.ready.WithLabelValues(certName).Set(0)
+	}
+}
+
+// reconcileRefreshRequest implements a small finite-state-machine driven by
+// RefreshCertificateAnnotation and RefreshCertificateStatusAnnotation, allowing an operator to force an
+// out-of-band re-issuance of the istiod cert (e.g. for emergency key rotation) by annotating the managed
+// Certificate. It is idempotent: a reconcile which finds a refresh already in-progress will not trigger
+// another one.
+func (dicp *DynamicIstiodCertProvisioner) reconcileRefreshRequest(ctx context.Context, cert *cmapi.Certificate) error {
+	switch cert.Annotations[RefreshCertificateStatusAnnotation] {
+	case RefreshStatusInProgress:
+		rotated, err := dicp.secretRotatedSince(ctx, cert.Spec.SecretName, cert.Annotations[refreshRequestedAtAnnotation])
+		if err != nil {
+			dicp.log.Error(err, "failed to check whether istiod cert secret has rotated", "cert_name", cert.Name, "cert_namespace", cert.Namespace)
+			updated, statusErr := dicp.setRefreshStatus(ctx, cert, RefreshStatusFailed, true)
+			if statusErr != nil {
+				return statusErr
+			}
+			*cert = *updated
+			return nil
+		}
+
+		if !rotated {
+			// still waiting for cert-manager to reissue the certificate
+			return nil
+		}
+
+		dicp.log.Info("istiod cert refresh completed", "cert_name", cert.Name, "cert_namespace", cert.Namespace)
+		updated, err := dicp.setRefreshStatus(ctx, cert, RefreshStatusDone, true)
+		if err != nil {
+			return err
+		}
+		*cert = *updated
+		return nil
+
+	default:
+		if cert.Annotations[RefreshCertificateAnnotation] != refreshRequestValue {
+			return nil
+		}
+
+		dicp.log.Info("forcing istiod cert refresh", "cert_name", cert.Name, "cert_namespace", cert.Namespace)
+
+		if err := dicp.secretClient.Delete(ctx, cert.Spec.SecretName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete istiod cert secret to force reissuance: %s", err)
+		}
+
+		if cert.Annotations == nil {
+			cert.Annotations = map[string]string{}
+		}
+		cert.Annotations[refreshRequestedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+
+		updated, err := dicp.setRefreshStatus(ctx, cert, RefreshStatusInProgress, false)
+		if err != nil {
+			return err
+		}
+		*cert = *updated
+		return nil
+	}
+}
+
+// setRefreshStatus updates RefreshCertificateStatusAnnotation on the managed Certificate to the given
+// status. When clearRequest is true, the original RefreshCertificateAnnotation trigger is also cleared, so
+// that a completed (or failed) refresh doesn't keep being re-triggered on every reconcile. It returns the
+// Certificate as updated by the API server, so that callers keep working with a fresh ResourceVersion
+// instead of issuing a subsequent update against a now-stale object.
+func (dicp *DynamicIstiodCertProvisioner) setRefreshStatus(ctx context.Context, cert *cmapi.Certificate, status string, clearRequest bool) (*cmapi.Certificate, error) {
+	if cert.Annotations == nil {
+		cert.Annotations = map[string]string{}
+	}
+
+	cert.Annotations[RefreshCertificateStatusAnnotation] = status
+
+	if clearRequest {
+		delete(cert.Annotations, RefreshCertificateAnnotation)
+	}
+
+	updated, err := dicp.certManagerClient.Update(ctx, cert, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update istiod cert refresh status annotation: %s", err)
+	}
+
+	return updated, nil
+}
+
+// secretRotatedSince returns true if the tls.crt currently stored in the named Secret has a notBefore
+// time after requestedAt, indicating cert-manager has issued a new certificate since the refresh was
+// requested.
+func (dicp *DynamicIstiodCertProvisioner) secretRotatedSince(ctx context.Context, secretName, requestedAt string) (bool, error) {
+	requestedAtTime, err := time.Parse(time.RFC3339, requestedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse refresh request timestamp %q: %s", requestedAt, err)
+	}
+
+	secret, err := dicp.secretClient.Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// cert-manager hasn't recreated the secret yet
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch istiod cert secret: %s", err)
+	}
+
+	notBefore, err := certNotBefore(secret)
+	if err != nil {
+		return false, err
+	}
+
+	return notBefore.After(requestedAtTime), nil
+}
+
+// certNotBefore parses the notBefore time of the leaf certificate stored in a TLS Secret's tls.crt key.
+func certNotBefore(secret *corev1.Secret) (time.Time, error) {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, fmt.Errorf("failed to decode PEM block from %q key of secret %q", corev1.TLSCertKey, secret.Name)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate from secret %q: %s", secret.Name, err)
+	}
+
+	return cert.NotBefore, nil
 }
 
 // makeDNSNamesFromRevisions takes a list of istio revisions and produces a list of
@@ -248,15 +749,10 @@ func makeDNSNamesFromRevisions(namespace string, istioRevisions []string) (strin
 
 	// The default revision is a special case, and "default" isn't added to the DNS SAN, appearing as simply
 	// istiod.<namespace>.svc
-	defaultSAN := fmt.Sprintf("istiod.%s.svc", namespace)
+	defaultSAN := dnsNameForRevision(namespace, "default")
 
 	for _, revision := range istioRevisions {
-		if revision == "default" {
-			dnsNames = append(dnsNames, defaultSAN)
-			continue
-		}
-
-		dnsNames = append(dnsNames, fmt.Sprintf("istiod%s.%s.svc", revision, namespace))
+		dnsNames = append(dnsNames, dnsNameForRevision(namespace, revision))
 	}
 
 	// Always return the default SAN as the commonName to match the behaviour of the static istiod cert
@@ -275,3 +771,12 @@ func makeDNSNamesFromRevisions(namespace string, istioRevisions []string) (strin
 
 	return defaultSAN, dnsNames
 }
+
+// dnsNameForRevision returns the istiod DNS name for a single istio revision in the given namespace.
+func dnsNameForRevision(namespace, revision string) string {
+	if revision == "default" {
+		return fmt.Sprintf("istiod.%s.svc", namespace)
+	}
+
+	return fmt.Sprintf("istiod%s.%s.svc", revision, namespace)
+}
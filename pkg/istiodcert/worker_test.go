@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istiodcert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testCertName      = "istiod"
+	testCertNamespace = "istio-system"
+	testSecretName    = "istiod-tls"
+)
+
+func newTestProvisioner(t *testing.T, certObjects, secretObjects []runtime.Object) *DynamicIstiodCertProvisioner {
+	t.Helper()
+
+	cmClient := cmfake.NewSimpleClientset(certObjects...)
+	kubeClient := kubefake.NewSimpleClientset(secretObjects...)
+
+	return &DynamicIstiodCertProvisioner{
+		log:               logr.Discard(),
+		certManagerClient: cmClient.CertmanagerV1().Certificates(testCertNamespace),
+		secretClient:      kubeClient.CoreV1().Secrets(testCertNamespace),
+		opts: Options{
+			CertificateName:      testCertName,
+			CertificateNamespace: testCertNamespace,
+		},
+	}
+}
+
+func testCertificate(annotations map[string]string) *cmapi.Certificate {
+	return &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testCertName,
+			Namespace:   testCertNamespace,
+			Annotations: annotations,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: testSecretName,
+		},
+	}
+}
+
+// testSecretWithNotBefore builds a TLS Secret whose tls.crt has the given notBefore time, for exercising
+// secretRotatedSince/certNotBefore without talking to a real CA.
+func testSecretWithNotBefore(t *testing.T, notBefore time.Time) *corev1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "istiod.istio-system.svc"},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create test certificate: %s", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testCertNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: certPEM,
+		},
+	}
+}
+
+func TestReconcileRefreshRequest_TriggersRefresh(t *testing.T) {
+	cert := testCertificate(map[string]string{RefreshCertificateAnnotation: refreshRequestValue})
+	secret := testSecretWithNotBefore(t, time.Now().Add(-time.Hour))
+
+	dicp := newTestProvisioner(t, []runtime.Object{cert}, []runtime.Object{secret})
+
+	working := cert.DeepCopy()
+	if err := dicp.reconcileRefreshRequest(context.Background(), working); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if working.Annotations[RefreshCertificateStatusAnnotation] != RefreshStatusInProgress {
+		t.Errorf("expected status %q, got %q", RefreshStatusInProgress, working.Annotations[RefreshCertificateStatusAnnotation])
+	}
+
+	if _, ok := working.Annotations[refreshRequestedAtAnnotation]; !ok {
+		t.Error("expected refreshRequestedAtAnnotation to be set")
+	}
+
+	if _, err := dicp.secretClient.Get(context.Background(), testSecretName, metav1.GetOptions{}); err == nil {
+		t.Error("expected backing secret to have been deleted to force reissuance")
+	}
+}
+
+func TestReconcileRefreshRequest_InProgressNotYetRotated(t *testing.T) {
+	requestedAt := time.Now().Add(-time.Minute)
+
+	cert := testCertificate(map[string]string{
+		RefreshCertificateStatusAnnotation: RefreshStatusInProgress,
+		refreshRequestedAtAnnotation:       requestedAt.Format(time.RFC3339),
+	})
+	// the secret's tls.crt predates the refresh request, so cert-manager hasn't reissued yet
+	secret := testSecretWithNotBefore(t, requestedAt.Add(-time.Hour))
+
+	dicp := newTestProvisioner(t, []runtime.Object{cert}, []runtime.Object{secret})
+
+	working := cert.DeepCopy()
+	if err := dicp.reconcileRefreshRequest(context.Background(), working); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if working.Annotations[RefreshCertificateStatusAnnotation] != RefreshStatusInProgress {
+		t.Errorf("expected status to remain %q while waiting for rotation, got %q", RefreshStatusInProgress, working.Annotations[RefreshCertificateStatusAnnotation])
+	}
+}
+
+func TestReconcileRefreshRequest_InProgressRotated(t *testing.T) {
+	requestedAt := time.Now().Add(-time.Minute)
+
+	cert := testCertificate(map[string]string{
+		RefreshCertificateAnnotation:       refreshRequestValue,
+		RefreshCertificateStatusAnnotation: RefreshStatusInProgress,
+		refreshRequestedAtAnnotation:       requestedAt.Format(time.RFC3339),
+	})
+	// the secret's tls.crt postdates the refresh request, so cert-manager has already reissued
+	secret := testSecretWithNotBefore(t, requestedAt.Add(time.Minute))
+
+	dicp := newTestProvisioner(t, []runtime.Object{cert}, []runtime.Object{secret})
+
+	working := cert.DeepCopy()
+	if err := dicp.reconcileRefreshRequest(context.Background(), working); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if working.Annotations[RefreshCertificateStatusAnnotation] != RefreshStatusDone {
+		t.Errorf("expected status %q, got %q", RefreshStatusDone, working.Annotations[RefreshCertificateStatusAnnotation])
+	}
+
+	if _, ok := working.Annotations[RefreshCertificateAnnotation]; ok {
+		t.Error("expected the trigger annotation to be cleared once the refresh completes")
+	}
+
+	stored, err := dicp.certManagerClient.Get(context.Background(), testCertName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to fetch updated cert: %s", err)
+	}
+
+	if working.ResourceVersion != stored.ResourceVersion {
+		t.Errorf("expected the caller's cert to carry the ResourceVersion returned by Update, got %q want %q", working.ResourceVersion, stored.ResourceVersion)
+	}
+}
+
+func TestReconcileRefreshRequest_NoRequest(t *testing.T) {
+	cert := testCertificate(nil)
+
+	dicp := newTestProvisioner(t, []runtime.Object{cert}, nil)
+
+	working := cert.DeepCopy()
+	if err := dicp.reconcileRefreshRequest(context.Background(), working); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := working.Annotations[RefreshCertificateStatusAnnotation]; ok {
+		t.Error("expected no status annotation to be written when no refresh was requested")
+	}
+}